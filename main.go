@@ -42,7 +42,7 @@ func main() {
 
 	fmt.Printf("Cache started (max size: %d, cleanup: %ds)\n", 
 		config.CacheMaxSize, config.CacheRefreshRate)
-	fmt.Println("Commands: set, get, delete, keys, size, cleanup, exit")
+	fmt.Println("Commands: set, get, delete, keys, size, cleanup, save, load, exit")
 
 	scanner := bufio.NewScanner(os.Stdin)
 	for {
@@ -105,11 +105,33 @@ func main() {
 			c.Cleanup()
 			fmt.Println("Cleanup completed")
 
+		case "save":
+			if len(parts) >= 2 {
+				if err := c.SaveFile(parts[1]); err != nil {
+					fmt.Printf("Save failed: %v\n", err)
+				} else {
+					fmt.Println("OK")
+				}
+			} else {
+				fmt.Println("Usage: save <path>")
+			}
+
+		case "load":
+			if len(parts) >= 2 {
+				if err := c.LoadFile(parts[1]); err != nil {
+					fmt.Printf("Load failed: %v\n", err)
+				} else {
+					fmt.Println("OK")
+				}
+			} else {
+				fmt.Println("Usage: load <path>")
+			}
+
 		case "exit", "quit":
 			return
 
 		default:
-			fmt.Println("Unknown command. Available: set, get, delete, keys, size, cleanup, exit")
+			fmt.Println("Unknown command. Available: set, get, delete, keys, size, cleanup, save, load, exit")
 		}
 	}
 }
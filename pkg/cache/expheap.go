@@ -0,0 +1,33 @@
+package cache
+
+// expEntry is one scheduled expiration: the key, the absolute expiration
+// time, and the generation of the cacheItem it was created for. The
+// generation lets the janitor recognize and skip stale entries left behind
+// when a key is updated or deleted, instead of removing them from the heap
+// eagerly.
+type expEntry struct {
+	expiration int64
+	key        string
+	generation uint64
+}
+
+// expHeap is a min-heap of expEntry ordered by expiration, giving the
+// janitor O(log n) insert and O(log n) access to the next key due to expire
+// instead of scanning every item on every tick.
+type expHeap []expEntry
+
+func (h expHeap) Len() int           { return len(h) }
+func (h expHeap) Less(i, j int) bool { return h[i].expiration < h[j].expiration }
+func (h expHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *expHeap) Push(x any) {
+	*h = append(*h, x.(expEntry))
+}
+
+func (h *expHeap) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	*h = old[:n-1]
+	return entry
+}
@@ -1,34 +1,58 @@
 package cache
 
 import (
+	"container/heap"
 	"sync"
-	"sync/atomic"
 	"time"
 )
 
 type InMemoryCache struct {
-	mu       sync.RWMutex
-	items    map[string]*cacheItem
-	stop     chan struct{}
-	interval time.Duration
-	maxSize  int
-	counter  uint64 // Atomic counter for access ordering
+	mu          sync.RWMutex
+	items       map[string]*cacheItem
+	policy      EvictionPolicy
+	expirations expHeap
+	inflight    map[string]*inflightLoad
+	onEvicted   func(key string, value any)
+	wake        chan struct{}
+	stop        chan struct{}
+	interval    time.Duration
+	maxSize     int
+	seqCounter  uint64
+}
+
+// inflightLoad tracks a single in-progress GetOrLoad call so that concurrent
+// callers for the same missing key share one loader invocation instead of
+// stampeding it.
+type inflightLoad struct {
+	wg    sync.WaitGroup
+	value any
+	err   error
 }
 
 type cacheItem struct {
 	value      any
 	expiration int64
-	accessSeq  uint64 // Sequence number for LRU eviction
+	generation uint64
+	seq        uint64 // access order, used only to preserve LRU order across Save/Load
 }
 
-func New(cleanupInterval time.Duration, maxSize int) *InMemoryCache {
+// New creates an InMemoryCache. By default eviction is LRU; pass
+// WithPolicy(cache.NewLFU()) or WithPolicy(cache.NewFIFO()) to change it.
+func New(cleanupInterval time.Duration, maxSize int, opts ...Option) *InMemoryCache {
 	c := &InMemoryCache{
 		items:    make(map[string]*cacheItem),
+		policy:   NewLRU(),
+		inflight: make(map[string]*inflightLoad),
+		wake:     make(chan struct{}, 1),
 		stop:     make(chan struct{}),
 		interval: cleanupInterval,
 		maxSize:  maxSize,
 	}
-	
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	if cleanupInterval > 0 {
 		go c.cleanup()
 	}
@@ -38,29 +62,97 @@ func New(cleanupInterval time.Duration, maxSize int) *InMemoryCache {
 func (c *InMemoryCache) Set(key string, value any, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.setLocked(key, value, ttl)
+}
 
+// setLocked is the body of Set. Caller must hold c.mu.
+func (c *InMemoryCache) setLocked(key string, value any, ttl time.Duration) {
 	now := time.Now()
 	expiration := now.Add(ttl).UnixNano()
-	seq := atomic.AddUint64(&c.counter, 1)
 
-	// Если элемент существует - обновляем
+	// Если элемент существует - обновляем и уведомляем политику о доступе
 	if item, exists := c.items[key]; exists {
 		item.value = value
 		item.expiration = expiration
-		item.accessSeq = seq
+		item.generation++
+		c.seqCounter++
+		item.seq = c.seqCounter
+		heap.Push(&c.expirations, expEntry{expiration: expiration, key: key, generation: item.generation})
+		c.policy.OnAccess(key)
+		c.wakeJanitor()
 		return
 	}
 
-	// Если достигнут максимальный размер, удаляем самый старый элемент (LRU)
+	// Если достигнут максимальный размер, вытесняем элемент согласно политике
 	if c.maxSize > 0 && len(c.items) >= c.maxSize {
-		c.evictLRU()
+		c.evict()
 	}
 
-	c.items[key] = &cacheItem{
+	c.seqCounter++
+	item := &cacheItem{
 		value:      value,
 		expiration: expiration,
-		accessSeq:  seq,
+		seq:        c.seqCounter,
 	}
+	c.items[key] = item
+	heap.Push(&c.expirations, expEntry{expiration: expiration, key: key, generation: item.generation})
+	c.policy.OnInsert(key)
+	c.wakeJanitor()
+}
+
+// GetOrLoad returns the cached value for key if present, otherwise calls
+// loader exactly once and caches its result with the given ttl. Concurrent
+// GetOrLoad calls for the same missing key block on the same in-flight
+// loader call and share its result instead of each calling loader themselves.
+func (c *InMemoryCache) GetOrLoad(key string, ttl time.Duration, loader func() (any, error)) (any, error) {
+	c.mu.Lock()
+
+	if item, exists := c.items[key]; exists {
+		if time.Now().UnixNano() <= item.expiration {
+			c.seqCounter++
+			item.seq = c.seqCounter
+			c.policy.OnAccess(key)
+			value := item.value
+			c.mu.Unlock()
+			return value, nil
+		}
+		c.removeItem(key)
+	}
+
+	if load, exists := c.inflight[key]; exists {
+		c.mu.Unlock()
+		load.wg.Wait()
+		return load.value, load.err
+	}
+
+	load := &inflightLoad{}
+	load.wg.Add(1)
+	c.inflight[key] = load
+	c.mu.Unlock()
+
+	value, err := loader()
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if err == nil {
+		c.setLocked(key, value, ttl)
+	}
+	c.mu.Unlock()
+
+	load.value, load.err = value, err
+	load.wg.Done()
+
+	return value, err
+}
+
+// OnEvicted registers f to be called whenever an item is removed from the
+// cache, whether by LRU/LFU/FIFO eviction, TTL expiry, janitor cleanup, or
+// explicit Delete. Only one callback can be registered; calling OnEvicted
+// again replaces it.
+func (c *InMemoryCache) OnEvicted(f func(key string, value any)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvicted = f
 }
 
 func (c *InMemoryCache) Get(key string) (any, bool) {
@@ -75,19 +167,23 @@ func (c *InMemoryCache) Get(key string) (any, bool) {
 	// Проверяем не истек ли TTL
 	now := time.Now().UnixNano()
 	if now > item.expiration {
-		delete(c.items, key)
+		c.removeItem(key)
 		return nil, false
 	}
 
-	// Обновляем последовательность доступа для LRU
-	item.accessSeq = atomic.AddUint64(&c.counter, 1)
+	c.seqCounter++
+	item.seq = c.seqCounter
+	c.policy.OnAccess(key)
 	return item.value, true
 }
 
 func (c *InMemoryCache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.items, key)
+
+	if _, exists := c.items[key]; exists {
+		c.removeItem(key)
+	}
 }
 
 func (c *InMemoryCache) Exists(key string) bool {
@@ -118,7 +214,7 @@ func (c *InMemoryCache) Keys() []string {
 func (c *InMemoryCache) Size() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	
+
 	now := time.Now().UnixNano()
 	count := 0
 	for _, item := range c.items {
@@ -129,55 +225,89 @@ func (c *InMemoryCache) Size() int {
 	return count
 }
 
+// Cleanup drains every currently expired entry. It is safe to call manually
+// at any time in addition to the background janitor.
 func (c *InMemoryCache) Cleanup() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.cleanupExpired()
+	c.drainExpired()
 }
 
-// Удаляет элемент с наименьшим accessSeq (LRU)
-func (c *InMemoryCache) evictLRU() {
-	if len(c.items) == 0 {
+// evict вытесняет один элемент согласно текущей политике (LRU/LFU/FIFO).
+func (c *InMemoryCache) evict() {
+	key, ok := c.policy.Evict()
+	if !ok {
 		return
 	}
-	
-	var lruKey string
-	var lruSeq uint64 = ^uint64(0) // Max uint64
+	item, exists := c.items[key]
+	delete(c.items, key)
+	if exists && c.onEvicted != nil {
+		c.onEvicted(key, item.value)
+	}
+}
 
-	for key, item := range c.items {
-		if item.accessSeq < lruSeq {
-			lruKey = key
-			lruSeq = item.accessSeq
+// drainExpired pops and deletes every expiration-heap entry that is due,
+// skipping stale entries left behind by updates and deletes (recognized by
+// generation mismatch). It returns how long until the next still-valid
+// entry expires, or a negative duration if the heap has nothing scheduled.
+// Caller must hold c.mu.
+func (c *InMemoryCache) drainExpired() time.Duration {
+	for c.expirations.Len() > 0 {
+		root := c.expirations[0]
+		item, exists := c.items[root.key]
+		if !exists || item.generation != root.generation {
+			heap.Pop(&c.expirations)
+			continue
 		}
+
+		now := time.Now().UnixNano()
+		if now >= root.expiration {
+			heap.Pop(&c.expirations)
+			c.removeItem(root.key)
+			continue
+		}
+
+		return time.Duration(root.expiration - now)
 	}
-	
-	if lruKey != "" {
-		delete(c.items, lruKey)
-	}
+	return -1
 }
 
-func (c *InMemoryCache) cleanupExpired() {
-	now := time.Now().UnixNano()
-	for key, item := range c.items {
-		if now > item.expiration {
-			delete(c.items, key)
-		}
+// wakeJanitor nudges the janitor goroutine to recompute its sleep duration,
+// e.g. after a new shorter-lived entry was added. It never blocks.
+func (c *InMemoryCache) wakeJanitor() {
+	if c.interval <= 0 {
+		return
+	}
+	select {
+	case c.wake <- struct{}{}:
+	default:
 	}
 }
 
+// cleanup is the background janitor: it sleeps until the next item is due
+// to expire (instead of polling on a fixed tick) and drains the heap when it
+// wakes, either because that deadline passed or because wakeJanitor fired.
 func (c *InMemoryCache) cleanup() {
 	if c.interval <= 0 {
 		return
 	}
 
-	ticker := time.NewTicker(c.interval)
-	defer ticker.Stop()
-
 	for {
+		c.mu.Lock()
+		sleep := c.drainExpired()
+		c.mu.Unlock()
+
+		if sleep < 0 {
+			sleep = c.interval
+		}
+
+		timer := time.NewTimer(sleep)
 		select {
-		case <-ticker.C:
-			c.Cleanup()
+		case <-timer.C:
+		case <-c.wake:
+			timer.Stop()
 		case <-c.stop:
+			timer.Stop()
 			return
 		}
 	}
@@ -186,3 +316,15 @@ func (c *InMemoryCache) cleanup() {
 func (c *InMemoryCache) Stop() {
 	close(c.stop)
 }
+
+// removeItem удаляет ключ из карты и уведомляет политику вытеснения. Истёкшую
+// запись в expirations не трогаем — она будет лениво пропущена при попытке
+// извлечения из кучи. Caller must hold c.mu.
+func (c *InMemoryCache) removeItem(key string) {
+	item, exists := c.items[key]
+	delete(c.items, key)
+	c.policy.OnDelete(key)
+	if exists && c.onEvicted != nil {
+		c.onEvicted(key, item.value)
+	}
+}
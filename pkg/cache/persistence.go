@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// cacheSnapshotItem is one persisted entry: key, value, absolute expiration
+// (UnixNano) and the access sequence at save time, used to restore items in
+// their original LRU order on Load.
+type cacheSnapshotItem struct {
+	Key        string
+	Value      any
+	Expiration int64
+	Seq        uint64
+}
+
+// RegisterType registers a concrete type that will be stored in the cache as
+// a value, so Save/Load (which serialize values as the any interface via
+// encoding/gob) know how to encode and decode it. Call it once at startup
+// for every non-built-in type you plan to cache, e.g.:
+//
+//	cache.RegisterType(MyStruct{})
+func RegisterType(v any) {
+	gob.Register(v)
+}
+
+// Save writes every live, non-expired item to w as a sequence of
+// length-prefixed gob records, oldest-accessed first. If a value cannot be
+// encoded (e.g. its type was never registered), Save stops and returns an
+// error; the records written before it stay in w. On the reading side, Load
+// skips a record that fails to decode and keeps going.
+func (c *InMemoryCache) Save(w io.Writer) error {
+	c.mu.RLock()
+	now := time.Now().UnixNano()
+	items := make([]cacheSnapshotItem, 0, len(c.items))
+	for key, item := range c.items {
+		if now > item.expiration {
+			continue
+		}
+		items = append(items, cacheSnapshotItem{
+			Key:        key,
+			Value:      item.value,
+			Expiration: item.expiration,
+			Seq:        item.seq,
+		})
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Seq < items[j].Seq })
+
+	for _, item := range items {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(item); err != nil {
+			return fmt.Errorf("cache: encode %q: %w", item.Key, err)
+		}
+		if err := binary.Write(w, binary.BigEndian, uint32(buf.Len())); err != nil {
+			return fmt.Errorf("cache: write record length: %w", err)
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("cache: write record: %w", err)
+		}
+	}
+	return nil
+}
+
+// Load reads a stream written by Save and merges it into the cache, skipping
+// already-expired entries and any record whose value fails to decode (e.g.
+// because its concrete type was never passed to RegisterType). Items are
+// replayed in their saved order, so LRU ordering is preserved for the
+// default policy.
+func (c *InMemoryCache) Load(r io.Reader) error {
+	now := time.Now().UnixNano()
+
+	for {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("cache: read record length: %w", err)
+		}
+
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return fmt.Errorf("cache: read record: %w", err)
+		}
+
+		var item cacheSnapshotItem
+		if err := gob.NewDecoder(bytes.NewReader(buf)).Decode(&item); err != nil {
+			continue
+		}
+
+		if now > item.Expiration {
+			continue
+		}
+
+		c.mu.Lock()
+		c.setLocked(item.Key, item.Value, time.Duration(item.Expiration-now))
+		c.mu.Unlock()
+	}
+}
+
+// SaveFile writes the cache to path, creating or truncating it.
+func (c *InMemoryCache) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("cache: create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return c.Save(f)
+}
+
+// LoadFile merges the snapshot at path into the cache.
+func (c *InMemoryCache) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("cache: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return c.Load(f)
+}
@@ -0,0 +1,108 @@
+package cache
+
+// EvictionPolicy decides which key to remove when a cache reaches its
+// maxSize. Implementations are not safe for concurrent use on their own;
+// InMemoryCache always calls them while holding its own mutex.
+type EvictionPolicy interface {
+	// OnAccess is called whenever a key is read (Get hit) or updated
+	// (Set on an existing key).
+	OnAccess(key string)
+	// OnInsert is called when a brand new key is added to the cache.
+	OnInsert(key string)
+	// OnDelete is called when a key is removed by explicit Delete or TTL
+	// expiry. It is not called for a key returned by Evict.
+	OnDelete(key string)
+	// Evict picks a key to remove to make room for a new one and drops it
+	// from the policy's own bookkeeping. ok is false if the policy has
+	// nothing to evict.
+	Evict() (key string, ok bool)
+}
+
+// Option configures an InMemoryCache at construction time.
+type Option func(*InMemoryCache)
+
+// WithPolicy overrides the eviction policy used when the cache reaches
+// maxSize. The default, used when no WithPolicy option is given, is
+// NewLRU().
+func WithPolicy(p EvictionPolicy) Option {
+	return func(c *InMemoryCache) {
+		c.policy = p
+	}
+}
+
+// keyList is a small intrusive doubly linked list of keys shared by the
+// order-based eviction policies (LRU, FIFO). It gives O(1) insert, remove
+// and move-to-back, with O(1) key lookup via the accompanying map.
+type keyList struct {
+	nodes      map[string]*keyNode
+	head, tail *keyNode // head = front (oldest), tail = back (newest)
+}
+
+type keyNode struct {
+	key        string
+	prev, next *keyNode
+}
+
+func newKeyList() *keyList {
+	return &keyList{nodes: make(map[string]*keyNode)}
+}
+
+func (l *keyList) pushBack(key string) {
+	n := &keyNode{key: key}
+	l.nodes[key] = n
+	l.linkBack(n)
+}
+
+func (l *keyList) linkBack(n *keyNode) {
+	n.prev = l.tail
+	n.next = nil
+	if l.tail != nil {
+		l.tail.next = n
+	} else {
+		l.head = n
+	}
+	l.tail = n
+}
+
+func (l *keyList) unlink(n *keyNode) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		l.head = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		l.tail = n.prev
+	}
+	n.prev = nil
+	n.next = nil
+}
+
+func (l *keyList) moveToBack(key string) {
+	n, ok := l.nodes[key]
+	if !ok || n == l.tail {
+		return
+	}
+	l.unlink(n)
+	l.linkBack(n)
+}
+
+func (l *keyList) remove(key string) {
+	n, ok := l.nodes[key]
+	if !ok {
+		return
+	}
+	l.unlink(n)
+	delete(l.nodes, key)
+}
+
+// popFront removes and returns the oldest key in the list.
+func (l *keyList) popFront() (string, bool) {
+	if l.head == nil {
+		return "", false
+	}
+	key := l.head.key
+	l.remove(key)
+	return key, true
+}
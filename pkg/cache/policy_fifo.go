@@ -0,0 +1,19 @@
+package cache
+
+// fifoPolicy evicts keys in the order they were inserted, ignoring access
+// patterns entirely.
+type fifoPolicy struct {
+	list *keyList
+}
+
+// NewFIFO returns an EvictionPolicy that evicts keys in insertion order.
+func NewFIFO() EvictionPolicy {
+	return &fifoPolicy{list: newKeyList()}
+}
+
+func (p *fifoPolicy) OnAccess(key string) {}
+func (p *fifoPolicy) OnInsert(key string) { p.list.pushBack(key) }
+func (p *fifoPolicy) OnDelete(key string) { p.list.remove(key) }
+func (p *fifoPolicy) Evict() (string, bool) {
+	return p.list.popFront()
+}
@@ -0,0 +1,145 @@
+package cache
+
+// lfuPolicy implements O(1) LFU eviction (the Ketan Shah / "LFU cache"
+// scheme): a doubly linked list of frequency nodes, each frequency node
+// owning a doubly linked list of the items currently at that frequency.
+// On access an item moves to the next-higher frequency node, creating it
+// if it doesn't exist yet. Eviction pops the head of the lowest-frequency
+// node's item list. A frequency node that becomes empty is unlinked.
+type lfuPolicy struct {
+	items map[string]*lfuItem
+	head  *lfuFreq // lowest frequency present
+}
+
+type lfuFreq struct {
+	freq       int
+	itemHead   *lfuItem
+	itemTail   *lfuItem
+	prev, next *lfuFreq
+}
+
+type lfuItem struct {
+	key        string
+	freqNode   *lfuFreq
+	prev, next *lfuItem
+}
+
+// NewLFU returns an EvictionPolicy that evicts the least frequently used key,
+// breaking ties by least recently used among items at that frequency.
+func NewLFU() EvictionPolicy {
+	return &lfuPolicy{items: make(map[string]*lfuItem)}
+}
+
+func (p *lfuPolicy) OnInsert(key string) {
+	freqNode := p.head
+	if freqNode == nil || freqNode.freq != 1 {
+		freqNode = p.insertFreqAfter(nil, 1)
+	}
+	item := &lfuItem{key: key, freqNode: freqNode}
+	p.pushItem(freqNode, item)
+	p.items[key] = item
+}
+
+func (p *lfuPolicy) OnAccess(key string) {
+	item, ok := p.items[key]
+	if !ok {
+		return
+	}
+	cur := item.freqNode
+	next := cur.next
+	if next == nil || next.freq != cur.freq+1 {
+		next = p.insertFreqAfter(cur, cur.freq+1)
+	}
+
+	p.removeItem(cur, item)
+	item.freqNode = next
+	p.pushItem(next, item)
+
+	if cur.itemHead == nil {
+		p.removeFreq(cur)
+	}
+}
+
+func (p *lfuPolicy) OnDelete(key string) {
+	item, ok := p.items[key]
+	if !ok {
+		return
+	}
+	delete(p.items, key)
+	freqNode := item.freqNode
+	p.removeItem(freqNode, item)
+	if freqNode.itemHead == nil {
+		p.removeFreq(freqNode)
+	}
+}
+
+func (p *lfuPolicy) Evict() (string, bool) {
+	if p.head == nil {
+		return "", false
+	}
+	item := p.head.itemHead
+	if item == nil {
+		return "", false
+	}
+	key := item.key
+	p.OnDelete(key)
+	return key, true
+}
+
+// insertFreqAfter creates a new frequency node with the given freq right
+// after "after" (or at the head if after is nil) and links it in.
+func (p *lfuPolicy) insertFreqAfter(after *lfuFreq, freq int) *lfuFreq {
+	n := &lfuFreq{freq: freq}
+	if after == nil {
+		n.next = p.head
+		if p.head != nil {
+			p.head.prev = n
+		}
+		p.head = n
+		return n
+	}
+	n.prev = after
+	n.next = after.next
+	if after.next != nil {
+		after.next.prev = n
+	}
+	after.next = n
+	return n
+}
+
+func (p *lfuPolicy) removeFreq(n *lfuFreq) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		p.head = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+}
+
+func (p *lfuPolicy) pushItem(freqNode *lfuFreq, item *lfuItem) {
+	item.prev = freqNode.itemTail
+	item.next = nil
+	if freqNode.itemTail != nil {
+		freqNode.itemTail.next = item
+	} else {
+		freqNode.itemHead = item
+	}
+	freqNode.itemTail = item
+}
+
+func (p *lfuPolicy) removeItem(freqNode *lfuFreq, item *lfuItem) {
+	if item.prev != nil {
+		item.prev.next = item.next
+	} else {
+		freqNode.itemHead = item.next
+	}
+	if item.next != nil {
+		item.next.prev = item.prev
+	} else {
+		freqNode.itemTail = item.prev
+	}
+	item.prev = nil
+	item.next = nil
+}
@@ -0,0 +1,19 @@
+package cache
+
+// lruPolicy evicts the least recently used key. Access and insertion both
+// move the key to the back of the list; eviction pops the front.
+type lruPolicy struct {
+	list *keyList
+}
+
+// NewLRU returns an EvictionPolicy that evicts the least recently used key.
+func NewLRU() EvictionPolicy {
+	return &lruPolicy{list: newKeyList()}
+}
+
+func (p *lruPolicy) OnAccess(key string) { p.list.moveToBack(key) }
+func (p *lruPolicy) OnInsert(key string) { p.list.pushBack(key) }
+func (p *lruPolicy) OnDelete(key string) { p.list.remove(key) }
+func (p *lruPolicy) Evict() (string, bool) {
+	return p.list.popFront()
+}
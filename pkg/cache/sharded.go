@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// ShardedCache splits keys across N independent InMemoryCache instances to
+// reduce lock contention under concurrent workloads. Each shard has its own
+// mutex and its own LRU eviction, so a Set on one shard never blocks a Get
+// on another.
+type ShardedCache struct {
+	shards []*InMemoryCache
+	mask   uint64
+}
+
+// NewSharded creates a ShardedCache with the given number of shards. shards
+// is rounded up to the next power of two so keys can be dispatched with a
+// cheap mask instead of a modulo. cleanupInterval and maxSizePerShard are
+// forwarded to every underlying shard.
+func NewSharded(shards int, cleanupInterval time.Duration, maxSizePerShard int) *ShardedCache {
+	n := nextPowerOfTwo(shards)
+
+	sc := &ShardedCache{
+		shards: make([]*InMemoryCache, n),
+		mask:   uint64(n - 1),
+	}
+	for i := range sc.shards {
+		sc.shards[i] = New(cleanupInterval, maxSizePerShard)
+	}
+	return sc
+}
+
+func nextPowerOfTwo(n int) int {
+	if n < 1 {
+		n = 1
+	}
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// shardFor picks the shard for a key using FNV-1a, which is fast and spreads
+// short string keys well enough for load balancing across shards.
+func (sc *ShardedCache) shardFor(key string) *InMemoryCache {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return sc.shards[h.Sum64()&sc.mask]
+}
+
+func (sc *ShardedCache) Set(key string, value any, ttl time.Duration) {
+	sc.shardFor(key).Set(key, value, ttl)
+}
+
+func (sc *ShardedCache) Get(key string) (any, bool) {
+	return sc.shardFor(key).Get(key)
+}
+
+func (sc *ShardedCache) Delete(key string) {
+	sc.shardFor(key).Delete(key)
+}
+
+func (sc *ShardedCache) Exists(key string) bool {
+	return sc.shardFor(key).Exists(key)
+}
+
+// Keys aggregates the keys of every shard. The result is a snapshot: a key
+// may be added or removed by a concurrent writer before the caller sees it.
+func (sc *ShardedCache) Keys() []string {
+	var keys []string
+	for _, shard := range sc.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Size returns the total number of live items across all shards.
+func (sc *ShardedCache) Size() int {
+	total := 0
+	for _, shard := range sc.shards {
+		total += shard.Size()
+	}
+	return total
+}
+
+// Cleanup drains expired items from every shard.
+func (sc *ShardedCache) Cleanup() {
+	for _, shard := range sc.shards {
+		shard.Cleanup()
+	}
+}
+
+// Stop shuts down every shard's janitor goroutine.
+func (sc *ShardedCache) Stop() {
+	for _, shard := range sc.shards {
+		shard.Stop()
+	}
+}
@@ -0,0 +1,62 @@
+package cache
+
+import "time"
+
+// Typed is a type-safe wrapper around InMemoryCache: callers get a concrete
+// V back from Get instead of any, with no type assertion at the call site.
+// It shares the same storage, eviction policies and janitor behavior as
+// InMemoryCache; only the API is generic.
+type Typed[V any] struct {
+	c *InMemoryCache
+}
+
+// NewTyped creates a Typed[V] cache. Options are the same as New.
+func NewTyped[V any](cleanupInterval time.Duration, maxSize int, opts ...Option) *Typed[V] {
+	return &Typed[V]{c: New(cleanupInterval, maxSize, opts...)}
+}
+
+func (t *Typed[V]) Set(key string, value V, ttl time.Duration) {
+	t.c.Set(key, value, ttl)
+}
+
+// Get returns the value for key and whether it was found. On a miss (or if
+// a value of a different type was somehow stored under key) it returns the
+// zero value of V.
+func (t *Typed[V]) Get(key string) (V, bool) {
+	raw, exists := t.c.Get(key)
+	if !exists {
+		var zero V
+		return zero, false
+	}
+
+	value, ok := raw.(V)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return value, true
+}
+
+func (t *Typed[V]) Delete(key string) {
+	t.c.Delete(key)
+}
+
+func (t *Typed[V]) Exists(key string) bool {
+	return t.c.Exists(key)
+}
+
+func (t *Typed[V]) Keys() []string {
+	return t.c.Keys()
+}
+
+func (t *Typed[V]) Size() int {
+	return t.c.Size()
+}
+
+func (t *Typed[V]) Cleanup() {
+	t.c.Cleanup()
+}
+
+func (t *Typed[V]) Stop() {
+	t.c.Stop()
+}
@@ -0,0 +1,64 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/medvedev-v/in-memory-cache/pkg/cache"
+)
+
+// TestJanitorExpiresPromptlyWithoutTightTick verifies that a short-TTL entry
+// is swept well before a long cleanup interval would have ticked, since the
+// janitor now sleeps until the next scheduled expiration instead of polling.
+func TestJanitorExpiresPromptlyWithoutTightTick(t *testing.T) {
+	c := cache.New(time.Hour, 10)
+	defer c.Stop()
+
+	swept := make(chan string, 2)
+	c.OnEvicted(func(key string, value any) {
+		select {
+		case swept <- key:
+		default:
+		}
+	})
+
+	c.Set("short", "value", 20*time.Millisecond)
+	c.Set("long", "value", time.Hour)
+
+	time.Sleep(100 * time.Millisecond)
+
+	select {
+	case key := <-swept:
+		if key != "short" {
+			t.Errorf("expected the janitor to sweep %q, got %q", "short", key)
+		}
+	default:
+		t.Error("short-lived key was not swept by the janitor")
+	}
+
+	if c.Size() != 1 {
+		t.Errorf("expected only the long-lived key to remain, got size %d", c.Size())
+	}
+	if _, exists := c.Get("long"); !exists {
+		t.Error("long-lived key should still exist")
+	}
+}
+
+func TestJanitorSkipsStaleHeapEntriesAfterUpdate(t *testing.T) {
+	c := cache.New(20*time.Millisecond, 10)
+	defer c.Stop()
+
+	c.Set("key1", "value1", 30*time.Millisecond)
+	// Refresh with a much longer TTL before the first entry would expire.
+	c.Set("key1", "value2", time.Hour)
+
+	time.Sleep(100 * time.Millisecond)
+
+	val, exists := c.Get("key1")
+	if !exists {
+		t.Fatal("key1 should still exist after being refreshed with a longer TTL")
+	}
+	if val != "value2" {
+		t.Errorf("expected refreshed value, got %v", val)
+	}
+}
@@ -0,0 +1,135 @@
+package cache_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/medvedev-v/in-memory-cache/pkg/cache"
+)
+
+func TestGetOrLoadCachesResult(t *testing.T) {
+	c := cache.New(time.Minute, 10)
+	defer c.Stop()
+
+	var calls int32
+	loader := func() (any, error) {
+		atomic.AddInt32(&calls, 1)
+		return "loaded", nil
+	}
+
+	val, err := c.GetOrLoad("key1", time.Minute, loader)
+	if err != nil || val != "loaded" {
+		t.Fatalf("unexpected result: %v, %v", val, err)
+	}
+
+	val, err = c.GetOrLoad("key1", time.Minute, loader)
+	if err != nil || val != "loaded" {
+		t.Fatalf("unexpected result on second call: %v, %v", val, err)
+	}
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected loader to run exactly once, ran %d times", calls)
+	}
+}
+
+func TestGetOrLoadPropagatesError(t *testing.T) {
+	c := cache.New(time.Minute, 10)
+	defer c.Stop()
+
+	wantErr := errors.New("load failed")
+	_, err := c.GetOrLoad("key1", time.Minute, func() (any, error) {
+		return nil, wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+
+	if _, exists := c.Get("key1"); exists {
+		t.Error("a failed load should not populate the cache")
+	}
+}
+
+func TestGetOrLoadSingleFlight(t *testing.T) {
+	c := cache.New(time.Minute, 10)
+	defer c.Stop()
+
+	var calls int32
+	const goroutines = 50
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			_, err := c.GetOrLoad("shared", time.Minute, func() (any, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(20 * time.Millisecond)
+				return "value", nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected loader to run exactly once across %d goroutines, ran %d times", goroutines, calls)
+	}
+}
+
+func TestOnEvictedFiresOnLRUEviction(t *testing.T) {
+	c := cache.New(0, 1)
+	defer c.Stop()
+
+	var evictedKey string
+	var evictedValue any
+	c.OnEvicted(func(key string, value any) {
+		evictedKey, evictedValue = key, value
+	})
+
+	c.Set("key1", "value1", time.Minute)
+	c.Set("key2", "value2", time.Minute)
+
+	if evictedKey != "key1" || evictedValue != "value1" {
+		t.Errorf("expected eviction callback for key1/value1, got %q/%v", evictedKey, evictedValue)
+	}
+}
+
+func TestOnEvictedFiresOnDelete(t *testing.T) {
+	c := cache.New(0, 10)
+	defer c.Stop()
+
+	var evictedKey string
+	c.OnEvicted(func(key string, value any) {
+		evictedKey = key
+	})
+
+	c.Set("key1", "value1", time.Minute)
+	c.Delete("key1")
+
+	if evictedKey != "key1" {
+		t.Errorf("expected eviction callback on Delete, got %q", evictedKey)
+	}
+}
+
+func TestOnEvictedFiresOnExpiry(t *testing.T) {
+	c := cache.New(0, 10)
+	defer c.Stop()
+
+	var evictedKey string
+	c.OnEvicted(func(key string, value any) {
+		evictedKey = key
+	})
+
+	c.Set("key1", "value1", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	c.Get("key1")
+
+	if evictedKey != "key1" {
+		t.Errorf("expected eviction callback on TTL expiry, got %q", evictedKey)
+	}
+}
@@ -0,0 +1,27 @@
+package cache_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/medvedev-v/in-memory-cache/pkg/cache"
+)
+
+// BenchmarkSetOnFullCache demonstrates that Set on a cache already at
+// maxSize stays flat (O(1) eviction) rather than growing linearly with size.
+func BenchmarkSetOnFullCache(b *testing.B) {
+	const maxSize = 100000
+
+	c := cache.New(0, maxSize)
+	defer c.Stop()
+
+	for i := 0; i < maxSize; i++ {
+		c.Set(strconv.Itoa(i), i, time.Minute)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Set(strconv.Itoa(maxSize+i), i, time.Minute)
+	}
+}
@@ -0,0 +1,125 @@
+package cache_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/medvedev-v/in-memory-cache/pkg/cache"
+)
+
+func TestSaveLoadFileRoundTrip(t *testing.T) {
+	src := cache.New(0, 10)
+	defer src.Stop()
+
+	src.Set("key1", "value1", time.Minute)
+	src.Set("key2", "value2", time.Minute)
+	src.Set("key3", "value3", time.Minute)
+
+	// Touch key1 so it is most recently used; key2 is now the LRU candidate.
+	src.Get("key1")
+
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+	if err := src.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile failed: %v", err)
+	}
+
+	dst := cache.New(0, 10)
+	defer dst.Stop()
+
+	if err := dst.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		key  string
+		want string
+	}{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	} {
+		val, exists := dst.Get(tc.key)
+		if !exists || val != tc.want {
+			t.Errorf("Get(%q) = %v, %v; want %q, true", tc.key, val, exists, tc.want)
+		}
+	}
+
+	// key2 was LRU in the source cache and should still be LRU after reload:
+	// loading into a maxSize-2 cache replays key2, key3, key1, so key2 is
+	// evicted when key1 arrives, and adding key4 then evicts key3, keeping key1.
+	dst2 := cache.New(0, 2)
+	defer dst2.Stop()
+	if err := dst2.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	dst2.Set("key4", "value4", time.Minute)
+
+	if _, exists := dst2.Get("key2"); exists {
+		t.Error("key2 should have been evicted as LRU after reload")
+	}
+	if _, exists := dst2.Get("key1"); !exists {
+		t.Error("key1 should survive as the most recently used key after reload")
+	}
+}
+
+func TestLoadSkipsExpiredEntries(t *testing.T) {
+	src := cache.New(0, 10)
+	defer src.Stop()
+
+	src.Set("expired", "value", 10*time.Millisecond)
+	src.Set("alive", "value", time.Minute)
+
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+
+	time.Sleep(20 * time.Millisecond)
+	if err := src.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile failed: %v", err)
+	}
+
+	dst := cache.New(0, 10)
+	defer dst.Stop()
+	if err := dst.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	if _, exists := dst.Get("expired"); exists {
+		t.Error("expired entry should not have been saved")
+	}
+	if _, exists := dst.Get("alive"); !exists {
+		t.Error("non-expired entry should survive the round trip")
+	}
+}
+
+type snapshotStruct struct {
+	Name string
+	Age  int
+}
+
+func TestSaveLoadCustomType(t *testing.T) {
+	cache.RegisterType(snapshotStruct{})
+
+	src := cache.New(0, 10)
+	defer src.Stop()
+	src.Set("user", snapshotStruct{Name: "Ada", Age: 36}, time.Minute)
+
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+	if err := src.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile failed: %v", err)
+	}
+
+	dst := cache.New(0, 10)
+	defer dst.Stop()
+	if err := dst.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	val, exists := dst.Get("user")
+	if !exists {
+		t.Fatal("expected user to exist after reload")
+	}
+	got, ok := val.(snapshotStruct)
+	if !ok || got != (snapshotStruct{Name: "Ada", Age: 36}) {
+		t.Errorf("unexpected value after reload: %#v", val)
+	}
+}
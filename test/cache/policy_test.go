@@ -0,0 +1,97 @@
+package cache_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/medvedev-v/in-memory-cache/pkg/cache"
+)
+
+func TestLFUPolicyKeepsHotKeyAlive(t *testing.T) {
+	c := cache.New(0, 3, cache.WithPolicy(cache.NewLFU()))
+	defer c.Stop()
+
+	c.Set("key1", "value1", time.Minute)
+	c.Set("key2", "value2", time.Minute)
+	c.Set("key3", "value3", time.Minute)
+
+	// Repeatedly access key1 so its frequency stays far above everything else.
+	for i := 0; i < 20; i++ {
+		c.Get("key1")
+	}
+
+	// Insert many cold keys; each Set evicts the current least-frequently-used key.
+	for i := 0; i < 10; i++ {
+		c.Set(fmt.Sprintf("cold%d", i), i, time.Minute)
+	}
+
+	if _, exists := c.Get("key1"); !exists {
+		t.Error("frequently accessed key1 should survive LFU eviction")
+	}
+}
+
+func TestLFUPolicyEvictsColdKeyFirst(t *testing.T) {
+	c := cache.New(0, 2, cache.WithPolicy(cache.NewLFU()))
+	defer c.Stop()
+
+	c.Set("hot", "value", time.Minute)
+	c.Set("cold", "value", time.Minute)
+
+	// Access hot several times but never touch cold again.
+	c.Get("hot")
+	c.Get("hot")
+	c.Get("hot")
+
+	c.Set("newcomer", "value", time.Minute)
+
+	if _, exists := c.Get("cold"); exists {
+		t.Error("cold key should have been evicted as least frequently used")
+	}
+	if _, exists := c.Get("hot"); !exists {
+		t.Error("hot key should still exist")
+	}
+}
+
+func TestLRUWouldEvictHotKeyIfUntouchedBriefly(t *testing.T) {
+	// Sanity check that the default LRU policy behaves differently from LFU:
+	// a key that goes untouched is evicted even if it was accessed heavily earlier.
+	c := cache.New(0, 2)
+	defer c.Stop()
+
+	c.Set("key1", "value1", time.Minute)
+	for i := 0; i < 20; i++ {
+		c.Get("key1")
+	}
+
+	c.Set("key2", "value2", time.Minute)
+	c.Set("key3", "value3", time.Minute)
+
+	if _, exists := c.Get("key1"); exists {
+		t.Error("LRU should evict key1 once it stops being the most recently used")
+	}
+}
+
+func TestFIFOPolicyEvictsInInsertionOrder(t *testing.T) {
+	c := cache.New(0, 2, cache.WithPolicy(cache.NewFIFO()))
+	defer c.Stop()
+
+	c.Set("key1", "value1", time.Minute)
+	c.Set("key2", "value2", time.Minute)
+
+	// Unlike LRU, accessing key1 must not save it from FIFO eviction.
+	c.Get("key1")
+	c.Get("key1")
+
+	c.Set("key3", "value3", time.Minute)
+
+	if _, exists := c.Get("key1"); exists {
+		t.Error("FIFO should evict key1 as the oldest inserted key regardless of access")
+	}
+	if _, exists := c.Get("key2"); !exists {
+		t.Error("key2 should still exist")
+	}
+	if _, exists := c.Get("key3"); !exists {
+		t.Error("key3 should exist")
+	}
+}
@@ -0,0 +1,53 @@
+package cache_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/medvedev-v/in-memory-cache/pkg/cache"
+)
+
+// mixedWorkload runs an 80/20 read/write workload against sc using
+// goroutines concurrent workers, each performing one operation per benchmark
+// iteration.
+func mixedWorkload(b *testing.B, sc *cache.ShardedCache, goroutines int) {
+	const keyspace = 1000
+
+	for i := 0; i < keyspace; i++ {
+		sc.Set(strconv.Itoa(i), i, time.Minute)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(seed int) {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(int64(seed)))
+				key := strconv.Itoa(r.Intn(keyspace))
+				if r.Intn(100) < 80 {
+					sc.Get(key)
+				} else {
+					sc.Set(key, r.Int(), time.Minute)
+				}
+			}(i*goroutines + g)
+		}
+		wg.Wait()
+	}
+}
+
+func BenchmarkShardedCacheMixedWorkload(b *testing.B) {
+	for _, shards := range []int{1, 8, 32} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			sc := cache.NewSharded(shards, time.Minute, 10000)
+			defer sc.Stop()
+			mixedWorkload(b, sc, 16)
+		})
+	}
+}
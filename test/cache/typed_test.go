@@ -0,0 +1,72 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/medvedev-v/in-memory-cache/pkg/cache"
+)
+
+type MyStruct struct {
+	Name string
+	Age  int
+}
+
+func TestTypedCacheInt(t *testing.T) {
+	c := cache.NewTyped[int](time.Minute, 10)
+	defer c.Stop()
+
+	c.Set("count", 42, time.Minute)
+
+	val, exists := c.Get("count")
+	if !exists {
+		t.Fatal("expected count to exist")
+	}
+	if val != 42 {
+		t.Errorf("expected 42, got %d", val)
+	}
+
+	if missing, exists := c.Get("missing"); exists || missing != 0 {
+		t.Errorf("expected zero value and miss, got %d, %v", missing, exists)
+	}
+}
+
+func TestTypedCacheByteSlice(t *testing.T) {
+	c := cache.NewTyped[[]byte](time.Minute, 10)
+	defer c.Stop()
+
+	payload := []byte("hello world")
+	c.Set("blob", payload, time.Minute)
+
+	val, exists := c.Get("blob")
+	if !exists {
+		t.Fatal("expected blob to exist")
+	}
+	if string(val) != "hello world" {
+		t.Errorf("expected %q, got %q", "hello world", val)
+	}
+
+	if missing, exists := c.Get("missing"); exists || missing != nil {
+		t.Errorf("expected zero value and miss, got %v, %v", missing, exists)
+	}
+}
+
+func TestTypedCacheStruct(t *testing.T) {
+	c := cache.NewTyped[MyStruct](time.Minute, 10)
+	defer c.Stop()
+
+	want := MyStruct{Name: "Ada", Age: 36}
+	c.Set("user", want, time.Minute)
+
+	got, exists := c.Get("user")
+	if !exists {
+		t.Fatal("expected user to exist")
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if missing, exists := c.Get("missing"); exists || missing != (MyStruct{}) {
+		t.Errorf("expected zero value and miss, got %+v, %v", missing, exists)
+	}
+}